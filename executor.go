@@ -2,6 +2,7 @@ package classifier
 
 import (
 	"fmt"
+	"math"
 	"sync"
 )
 
@@ -11,12 +12,21 @@ const (
 	ErrEmptyText     = "[AstrA] Передан пустой текст"
 )
 
+// defaultAlpha задает параметр аддитивного (Лапласа) сглаживания по умолчанию
+const defaultAlpha = 1.0
+
 // Classifier описывает структуру классификатора
 type Classifier struct {
 	MapTokenToCategory map[string]map[string]int
 	CategoriesCount    map[string]int
-	tokenizer          Tokenizer
-	mu                 sync.RWMutex
+
+	categoryTokenTotals map[string]int      // Общее количество токенов, встреченных в категории
+	vocabulary          map[string]struct{} // Словарь всех токенов, встреченных при обучении
+
+	alpha float64 // Параметр аддитивного (Лапласа/Лидстоуна) сглаживания
+
+	tokenizer Tokenizer
+	mu        sync.RWMutex
 }
 
 // Option описывает дополнительный настройки классификатора
@@ -25,9 +35,12 @@ type Option func(c *Classifier) error
 // New создает новый классификатор со стандартным токенизатором
 func New(opts ...Option) *Classifier {
 	c := &Classifier{
-		MapTokenToCategory: make(map[string]map[string]int),
-		CategoriesCount:    make(map[string]int),
-		tokenizer:          NewTokenizer(),
+		MapTokenToCategory:  make(map[string]map[string]int),
+		CategoriesCount:     make(map[string]int),
+		categoryTokenTotals: make(map[string]int),
+		vocabulary:          make(map[string]struct{}),
+		alpha:               defaultAlpha,
+		tokenizer:           NewTokenizer(),
 	}
 
 	for _, opt := range opts {
@@ -44,20 +57,32 @@ func TokenizerCustom(t Tokenizer) Option {
 	}
 }
 
+// WithSmoothing задает параметр alpha аддитивного (Лапласа/Лидстоуна) сглаживания,
+// используемый при расчете P(token|cat) = (count(token,cat)+alpha) / (totalTokens(cat)+alpha*|V|)
+func WithSmoothing(alpha float64) Option {
+	return func(c *Classifier) error {
+		c.alpha = alpha
+		return nil
+	}
+}
+
 // Train производит обучение на категорию category по данным документа trainData
 func (c *Classifier) Train(trainData string, category string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	for t := range c.tokenizer.Tokenize(trainData) {
-		c.addToken(t, category)
+		c.addToken(t.Text, category)
 	}
 
 	c.addCategory(category)
 	return nil
 }
 
-// Classify производит попытку определить класс переданного текста в документа testData
+// Classify производит попытку определить класс переданного текста в документе data.
+// Оценка категорий ведется в логарифмическом пространстве во избежание underflow
+// на документах с большим количеством токенов, а coincidenceIndex нормализуется
+// обратно в вероятность через log-sum-exp
 func (c *Classifier) Classify(data string) (guessedClass string, coincidenceIndex float64, err error) {
 	if data == "" {
 		return "", 0.0, fmt.Errorf(ErrEmptyText)
@@ -66,12 +91,14 @@ func (c *Classifier) Classify(data string) (guessedClass string, coincidenceInde
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	// Определение максимального индекса совпадения
-	maxCoincidenceIndex := 0.0
+	logScores := make(map[string]float64)
+
+	maxLogScore := math.Inf(-1)
 	for _, cat := range c.getModelCategories() {
-		catCI := c.getProb(data, cat)
-		if catCI > maxCoincidenceIndex {
-			maxCoincidenceIndex = catCI
+		score := c.getLogProb(data, cat)
+		logScores[cat] = score
+		if score > maxLogScore {
+			maxLogScore = score
 			guessedClass = cat
 		}
 	}
@@ -79,30 +106,36 @@ func (c *Classifier) Classify(data string) (guessedClass string, coincidenceInde
 	if guessedClass == "" {
 		return "", 0.0, fmt.Errorf(ErrNotClassified)
 	}
-	return guessedClass, maxCoincidenceIndex, nil
+
+	coincidenceIndex = math.Exp(logScores[guessedClass] - logSumExp(logScores))
+	return guessedClass, coincidenceIndex, nil
 }
 
-// GetProb определяет индекс совпадения str с категориями модели и выдает лучший результат
+// GetProb определяет вероятность принадлежности str к каждой категории модели.
+// Возвращенные вероятности нормализованы через log-sum-exp и в сумме дают 1
 func (c *Classifier) GetProb(str string) (map[string]float64, string) {
-	probs := make(map[string]float64)
-
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	bestProb := 0.0
+	logScores := make(map[string]float64)
+
 	var respCat string
+	maxLogScore := math.Inf(-1)
 	for _, cat := range c.getModelCategories() {
-		prob := c.getProb(str, cat)
-		if prob > 0 {
-			probs[cat] = prob
-		}
-
-		if prob > bestProb {
-			bestProb = prob
+		score := c.getLogProb(str, cat)
+		logScores[cat] = score
+		if score > maxLogScore {
+			maxLogScore = score
 			respCat = cat
 		}
 	}
 
+	norm := logSumExp(logScores)
+	probs := make(map[string]float64, len(logScores))
+	for cat, score := range logScores {
+		probs[cat] = math.Exp(score - norm)
+	}
+
 	return probs, respCat
 }
 
@@ -112,6 +145,8 @@ func (c *Classifier) addToken(t string, cat string) {
 		c.MapTokenToCategory[t] = make(map[string]int)
 	}
 	c.MapTokenToCategory[t][cat]++
+	c.categoryTokenTotals[cat]++
+	c.vocabulary[t] = struct{}{}
 }
 
 // countTokensInCategory возвращает вес token в категории
@@ -122,42 +157,11 @@ func (c *Classifier) countTokenInCategory(token string, category string) float64
 	return 0.0
 }
 
-// calcTokenWeight возвращает общий вес токена в классификаторе
-func (c *Classifier) calcTokenWeight(token string) float64 {
-	var weight float64
-	for _, cat := range c.getModelCategories() {
-		weight += float64(c.MapTokenToCategory[token][cat])
-	}
-
-	// При отсутствии токена в классификаторе, возвращается минимальный вес
-	if weight > 0 {
-		return weight
-	}
-	return 0.001
-}
-
 // addCategory добавляет в модель новую категорию cat
 func (c *Classifier) addCategory(cat string) {
 	c.CategoriesCount[cat]++
 }
 
-// categoryTokensCount возвращает количество токенов в category
-func (c *Classifier) categoryTokensCount(cat string) float64 {
-	if _, ok := c.CategoriesCount[cat]; ok {
-		return float64(c.CategoriesCount[cat])
-	}
-	return 0.0
-}
-
-// countOverallTokens возращает общее количество токенов в модели
-func (c *Classifier) countOverallTokens() int {
-	sum := 0
-	for _, v := range c.CategoriesCount {
-		sum += v
-	}
-	return sum
-}
-
 // getModelCategories возвращает общий список категорий в модели
 func (c *Classifier) getModelCategories() []string {
 	var keys []string
@@ -167,40 +171,57 @@ func (c *Classifier) getModelCategories() []string {
 	return keys
 }
 
-// getTokenProb возвращает вероятность отношения токена к категории
-func (c *Classifier) getTokenProb(token string, category string) float64 {
-	if c.categoryTokensCount(category) == 0 {
-		return 0.0
+// getCategoryPrior возвращает априорную вероятность P(cat) как долю обучающих
+// документов, отнесенных к cat
+func (c *Classifier) getCategoryPrior(category string) float64 {
+	total := 0
+	for _, n := range c.CategoriesCount {
+		total += n
+	}
+	if total == 0 {
+		return 1.0 / float64(len(c.CategoriesCount))
 	}
+	return float64(c.CategoriesCount[category]) / float64(total)
+}
 
-	return c.countTokenInCategory(token, category) / c.categoryTokensCount(category)
+// getTokenProb определяет P(token|cat) с аддитивным (Лапласа/Лидстоуна) сглаживанием:
+// (count(token,cat)+alpha) / (totalTokens(cat)+alpha*|V|)
+func (c *Classifier) getTokenProb(token string, category string) float64 {
+	count := c.countTokenInCategory(token, category)
+	total := float64(c.categoryTokenTotals[category])
+	vocabSize := float64(len(c.vocabulary))
+	return (count + c.alpha) / (total + c.alpha*vocabSize)
 }
 
-// getProb получает вероятность принадлежности токена к категории с учетом их относительных весов
-func (c *Classifier) getProb(data string, category string) float64 {
-	// categoryProb := c.categoryTokensCount(category) / float64(c.countOverallTokens())
-	categoryProb := float64(1 / len(c.CategoriesCount))
-	docProb := c.getTextProb(data, category)
-	return docProb * categoryProb
+// getLogProb вычисляет log P(cat) + Σ log P(token|cat) для документа data и категории category
+func (c *Classifier) getLogProb(data string, category string) float64 {
+	return math.Log(c.getCategoryPrior(category)) + c.getTextLogProb(data, category)
 }
 
-// getDocumentProb определяет вероятность отношения текста data к cat
-func (c *Classifier) getTextProb(data string, cat string) float64 {
-	prob := 1.0
+// getTextLogProb суммирует логарифмы вероятностей токенов документа data для категории cat
+func (c *Classifier) getTextLogProb(data string, cat string) float64 {
+	logProb := 0.0
 	for t := range c.tokenizer.Tokenize(data) {
-		prob *= c.getWeightedProb(t, cat)
+		logProb += math.Log(c.getTokenProb(t.Text, cat))
 	}
-	return prob
+	return logProb
 }
 
-// getWeightedProb определяет взвешенную вероятность отношения токена к категории
-func (c *Classifier) getWeightedProb(token string, cat string) float64 {
-	sum := 0.0
-	prob := c.getTokenProb(token, cat)
-	for _, category := range c.getModelCategories() {
-		sum += c.countTokenInCategory(token, category)
+// logSumExp численно устойчиво вычисляет log(Σ exp(scores))
+func logSumExp(scores map[string]float64) float64 {
+	max := math.Inf(-1)
+	for _, s := range scores {
+		if s > max {
+			max = s
+		}
+	}
+	if math.IsInf(max, -1) {
+		return max
 	}
 
-	result := ((c.calcTokenWeight(token) * 1 / float64(len(c.CategoriesCount))) + (sum * prob)) / (1.0 + sum)
-	return result
+	sum := 0.0
+	for _, s := range scores {
+		sum += math.Exp(s - max)
+	}
+	return max + math.Log(sum)
 }
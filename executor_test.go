@@ -0,0 +1,56 @@
+package classifier
+
+import "testing"
+
+// TestClassifyLogSpaceScoring проверяет, что после обучения на паре непересекающихся
+// по словарю категорий Classify и GetProb (лог-пространство, нормализация через
+// log-sum-exp) относят документы к ожидаемой категории с вероятностью > 0.5
+func TestClassifyLogSpaceScoring(t *testing.T) {
+	c := New()
+	c.Train("кот собака хомяк", "animals")
+	c.Train("стол стул шкаф", "furniture")
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"кот хомяк", "animals"},
+		{"стол шкаф", "furniture"},
+	}
+
+	for _, tt := range tests {
+		gotClass, coincidenceIndex, err := c.Classify(tt.text)
+		if err != nil {
+			t.Fatalf("Classify(%q) returned error: %v", tt.text, err)
+		}
+		if gotClass != tt.want {
+			t.Errorf("Classify(%q) = %q, want %q", tt.text, gotClass, tt.want)
+		}
+		if coincidenceIndex <= 0.5 {
+			t.Errorf("Classify(%q) coincidenceIndex = %v, want > 0.5", tt.text, coincidenceIndex)
+		}
+
+		probs, respCat := c.GetProb(tt.text)
+		if respCat != tt.want {
+			t.Errorf("GetProb(%q) respCat = %q, want %q", tt.text, respCat, tt.want)
+		}
+
+		sum := 0.0
+		for _, p := range probs {
+			sum += p
+		}
+		if sum < 0.999 || sum > 1.001 {
+			t.Errorf("GetProb(%q) probabilities sum to %v, want ~1", tt.text, sum)
+		}
+	}
+}
+
+// TestClassifyEmptyText проверяет, что Classify возвращает ErrEmptyText на пустом документе
+func TestClassifyEmptyText(t *testing.T) {
+	c := New()
+	c.Train("кот собака", "animals")
+
+	if _, _, err := c.Classify(""); err == nil {
+		t.Error("Classify(\"\") expected an error, got nil")
+	}
+}
@@ -0,0 +1,40 @@
+package classifier
+
+import "strings"
+
+// asciiFoldMap содержит соответствия распространенных латинских букв с диакритикой
+// их базовым ASCII-эквивалентам
+var asciiFoldMap = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a', 'ā': 'a',
+	'ç': 'c', 'ć': 'c', 'č': 'c',
+	'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e', 'ē': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ī': 'i',
+	'ñ': 'n', 'ń': 'n',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o', 'ō': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ū': 'u',
+	'ý': 'y', 'ÿ': 'y',
+	'ß': 's',
+	'ž': 'z', 'ź': 'z', 'ż': 'z',
+}
+
+// RemoveLongFilter строит Predicate, отбрасывающий токены длиннее max символов
+func RemoveLongFilter(max int) Predicate {
+	return func(v string) bool {
+		return len([]rune(v)) <= max
+	}
+}
+
+// AsciiFoldingFilter приводит символы латиницы с диакритикой к их ASCII-эквивалентам
+// (например, "café" -> "cafe"). Заменяются только строчные буквы из asciiFoldMap:
+// заглавные буквы с диакритикой остаются без изменений
+func AsciiFoldingFilter(v string) string {
+	var b strings.Builder
+	for _, r := range v {
+		if folded, ok := asciiFoldMap[r]; ok {
+			b.WriteRune(folded)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
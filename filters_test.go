@@ -0,0 +1,42 @@
+package classifier
+
+import "testing"
+
+// TestRemoveLongFilter проверяет, что RemoveLongFilter отбрасывает токены длиннее max
+// символов и пропускает остальные
+func TestRemoveLongFilter(t *testing.T) {
+	filter := RemoveLongFilter(5)
+
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"short", true},
+		{"waytoolong", false},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		if got := filter(tt.word); got != tt.want {
+			t.Errorf("RemoveLongFilter(5)(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+// TestAsciiFoldingFilter проверяет приведение латиницы с диакритикой к ASCII-эквивалентам
+func TestAsciiFoldingFilter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"café", "cafe"},
+		{"naïve", "naive"},
+		{"plain", "plain"},
+	}
+
+	for _, tt := range tests {
+		if got := AsciiFoldingFilter(tt.in); got != tt.want {
+			t.Errorf("AsciiFoldingFilter(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Package jieba оборачивает легковесный сегментатор китайского текста в стиле Jieba
+// (словарное прямое сопоставление по наибольшей длине) и предоставляет его как
+// classifier.Tokenizer через classifier.MorphTokenizerAdapter.
+package jieba
+
+import (
+	"unicode"
+
+	classifier "github.com/ArtemYeremeev/AstrA"
+)
+
+// maxWordLen ограничивает длину слова, рассматриваемого при прямом сопоставлении
+// по словарю (forward maximum matching)
+const maxWordLen = 4
+
+// dictEntry описывает словарную статью: часть речи. Лемма не хранится, так как
+// для китайского языка она совпадает с поверхностной формой (ключом dict)
+type dictEntry struct {
+	pos string
+}
+
+// dict содержит небольшой базовый словарь часто встречающихся слов. В полноценной
+// интеграции он заменяется словарем настоящего Jieba
+var dict = map[string]dictEntry{
+	"我们": {"r"}, "你们": {"r"}, "他们": {"r"}, "我": {"r"}, "你": {"r"}, "他": {"r"}, "她": {"r"},
+	"是": {"v"}, "有": {"v"}, "不是": {"v"}, "喜欢": {"v"}, "想": {"v"}, "说": {"v"}, "做": {"v"}, "去": {"v"},
+	"中国": {"ns"}, "北京": {"ns"}, "公司": {"n"}, "问题": {"n"}, "时间": {"n"}, "朋友": {"n"}, "学生": {"n"},
+	"的": {"u"}, "了": {"u"}, "和": {"c"}, "也": {"d"}, "很": {"d"}, "非常": {"d"}, "在": {"p"},
+}
+
+// segmenter реализует classifier.MorphTokenizer через прямое сопоставление по наибольшей длине
+type segmenter struct{}
+
+// New создает classifier.Tokenizer, сегментирующий китайский текст в стиле Jieba
+func New() *classifier.MorphTokenizerAdapter {
+	return classifier.NewMorphTokenizerAdapter(&segmenter{})
+}
+
+// Segment разбивает text на Token-ы методом прямого сопоставления по наибольшей длине:
+// на каждой позиции выбирается самое длинное слово из dict, иначе один иероглиф
+func (s *segmenter) Segment(text string) []classifier.Token {
+	runes := []rune(text)
+
+	var tokens []classifier.Token
+	for i := 0; i < len(runes); {
+		if !unicode.Is(unicode.Han, runes[i]) {
+			i++
+			continue
+		}
+
+		matched := false
+		for n := maxWordLen; n >= 1; n-- {
+			if i+n > len(runes) {
+				continue
+			}
+
+			surface := string(runes[i : i+n])
+			if entry, ok := dict[surface]; ok {
+				tokens = append(tokens, classifier.Token{Surface: surface, POS: entry.pos})
+				i += n
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			tokens = append(tokens, classifier.Token{Surface: string(runes[i]), POS: "n"})
+			i++
+		}
+	}
+
+	return tokens
+}
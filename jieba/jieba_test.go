@@ -0,0 +1,28 @@
+package jieba
+
+import "testing"
+
+// TestSegmentForwardMaximumMatching проверяет, что Segment выбирает на каждой позиции
+// самое длинное совпадение из словаря, а не разбивает текст по одному иероглифу
+func TestSegmentForwardMaximumMatching(t *testing.T) {
+	s := &segmenter{}
+	tokens := s.Segment("我们是朋友")
+
+	want := []struct {
+		surface string
+		pos     string
+	}{
+		{"我们", "r"},
+		{"是", "v"},
+		{"朋友", "n"},
+	}
+
+	if len(tokens) != len(want) {
+		t.Fatalf("Segment() = %+v, want %d tokens", tokens, len(want))
+	}
+	for i, tok := range tokens {
+		if tok.Surface != want[i].surface || tok.POS != want[i].pos {
+			t.Errorf("Segment()[%d] = %+v, want Surface=%q POS=%q", i, tok, want[i].surface, want[i].pos)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package kagome оборачивает легковесный сегментатор японского текста в стиле Kagome
+// и предоставляет его как classifier.Tokenizer через classifier.MorphTokenizerAdapter.
+package kagome
+
+import (
+	"unicode"
+
+	classifier "github.com/ArtemYeremeev/AstrA"
+)
+
+// Известные глагольные и служебные окончания хираганы, используемые для грубой
+// классификации части речи без полноценного словаря
+var verbEndings = []string{"ます", "ました", "ません", "る", "た", "て", "ない"}
+var particles = map[string]struct{}{
+	"は": {}, "が": {}, "を": {}, "に": {}, "で": {}, "と": {}, "も": {}, "の": {}, "へ": {}, "から": {},
+}
+
+// segmenter реализует classifier.MorphTokenizer, разбивая текст на границах смены
+// письменности (кандзи/хирагана/катакана/прочее), что является типичной первой
+// стадией сегментаторов японского текста без полноценного словаря
+type segmenter struct{}
+
+// New создает classifier.Tokenizer, сегментирующий японский текст в стиле Kagome
+func New() *classifier.MorphTokenizerAdapter {
+	return classifier.NewMorphTokenizerAdapter(&segmenter{})
+}
+
+// Segment разбивает text на Token-ы по границам смены письменности и проставляет
+// приблизительную часть речи
+func (s *segmenter) Segment(text string) []classifier.Token {
+	var tokens []classifier.Token
+
+	runes := []rune(text)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && scriptOf(runes[i]) == scriptOf(runes[start]) {
+			continue
+		}
+
+		surface := string(runes[start:i])
+		if scriptOf(runes[start]) != scriptOther {
+			tokens = append(tokens, classifier.Token{
+				Surface: surface,
+				POS:     posOf(surface),
+			})
+		}
+		start = i
+	}
+
+	return tokens
+}
+
+type script int
+
+const (
+	scriptOther script = iota
+	scriptKanji
+	scriptHiragana
+	scriptKatakana
+)
+
+// scriptOf определяет письменность символа r
+func scriptOf(r rune) script {
+	switch {
+	case unicode.Is(unicode.Han, r):
+		return scriptKanji
+	case unicode.Is(unicode.Hiragana, r):
+		return scriptHiragana
+	case unicode.Is(unicode.Katakana, r):
+		return scriptKatakana
+	default:
+		return scriptOther
+	}
+}
+
+// posOf грубо определяет часть речи по письменности и известным окончаниям,
+// поскольку полноценный словарь в этом легковесном сегментаторе не используется
+func posOf(surface string) string {
+	if _, ok := particles[surface]; ok {
+		return "助詞" // частица
+	}
+
+	runes := []rune(surface)
+	if scriptOf(runes[0]) == scriptKanji {
+		return "名詞" // существительное
+	}
+
+	for _, ending := range verbEndings {
+		if len(surface) >= len(ending) && surface[len(surface)-len(ending):] == ending {
+			return "動詞" // глагол
+		}
+	}
+
+	return "助詞"
+}
@@ -0,0 +1,29 @@
+package kagome
+
+import "testing"
+
+// TestSegmentSplitsOnScriptBoundaries проверяет, что Segment разбивает текст на
+// границах смены письменности (кандзи/хирагана) и грубо проставляет часть речи
+func TestSegmentSplitsOnScriptBoundaries(t *testing.T) {
+	s := &segmenter{}
+	tokens := s.Segment("猫が鳴く")
+
+	want := []struct {
+		surface string
+		pos     string
+	}{
+		{"猫", "名詞"},
+		{"が", "助詞"},
+		{"鳴", "名詞"},
+		{"く", "助詞"},
+	}
+
+	if len(tokens) != len(want) {
+		t.Fatalf("Segment() = %+v, want %d tokens", tokens, len(want))
+	}
+	for i, tok := range tokens {
+		if tok.Surface != want[i].surface || tok.POS != want[i].pos {
+			t.Errorf("Segment()[%d] = %+v, want Surface=%q POS=%q", i, tok, want[i].surface, want[i].pos)
+		}
+	}
+}
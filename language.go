@@ -0,0 +1,51 @@
+package classifier
+
+import (
+	"github.com/ArtemYeremeev/AstrA/stemmer/en"
+	"github.com/ArtemYeremeev/AstrA/stemmer/ru"
+)
+
+// Language задает язык пайплайна токенизации, используемый для выбора
+// набора стоп-слов и стеммера
+type Language string
+
+const (
+	LangRU Language = "ru" // Русский язык
+	LangEN Language = "en" // Английский язык
+)
+
+// StopWords подключает в NewTokenizer набор стоп-слов языка lang как Predicate-фильтр
+func StopWords(lang Language) StdOption {
+	return func(t *StdTokenizer) {
+		t.filters = append(t.filters, StopWordFilter(resolveStopWords(lang)))
+		t.components = append(t.components, "stopwords:"+string(lang))
+	}
+}
+
+// Stemmer подключает в NewTokenizer стеммер языка lang как Mapper-трансформацию
+func Stemmer(lang Language) StdOption {
+	return func(t *StdTokenizer) {
+		t.transforms = append(t.transforms, resolveStemmer(lang))
+		t.components = append(t.components, "stemmer:"+string(lang))
+	}
+}
+
+// resolveStopWords возвращает набор стоп-слов для языка lang
+func resolveStopWords(lang Language) StopWordSet {
+	switch lang {
+	case LangEN:
+		return stopWordSetEN
+	default:
+		return stopWordSetRU
+	}
+}
+
+// resolveStemmer возвращает функцию стемминга для языка lang
+func resolveStemmer(lang Language) Mapper {
+	switch lang {
+	case LangEN:
+		return en.Stem
+	default:
+		return ru.Stem
+	}
+}
@@ -0,0 +1,40 @@
+package classifier
+
+import "testing"
+
+// TestStopWordsOption проверяet, что StopWords(lang) подключает в токенизатор
+// соответствующий языку набор стоп-слов, отбрасывая его токены из потока
+func TestStopWordsOption(t *testing.T) {
+	tok := NewTokenizer(Filters(), StopWords(LangEN))
+
+	var texts []string
+	for tk := range tok.Tokenize("the cat and the dog") {
+		texts = append(texts, tk.Text)
+	}
+
+	for _, word := range texts {
+		if word == "the" || word == "and" {
+			t.Errorf("Tokenize() returned stop word %q, want it filtered out", word)
+		}
+	}
+	if len(texts) != 2 {
+		t.Errorf("Tokenize() = %v, want 2 non-stopword tokens", texts)
+	}
+}
+
+// TestStemmerOption проверяет, что Stemmer(lang) подключает в токенизатор
+// стеммер соответствующего языка как Mapper-трансформацию
+func TestStemmerOption(t *testing.T) {
+	tok := NewTokenizer(Transforms(), Stemmer(LangEN))
+
+	var texts []string
+	for tk := range tok.Tokenize("flying flies") {
+		texts = append(texts, tk.Text)
+	}
+
+	for _, word := range texts {
+		if word == "flying" || word == "flies" {
+			t.Errorf("Tokenize() = %q, want it run through the EN stemmer", word)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package classifier
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Token описывает единицу морфологического разбора: исходную форму (Surface),
+// начальную форму/лемму (Base, может быть пустой) и часть речи (POS)
+type Token struct {
+	Surface string
+	Base    string
+	POS     string
+}
+
+// MorphTokenizer описывает морфологический анализатор, способный разбить текст
+// на Token-ы. Используется для языков без пробельных разделителей слов (CJK, тайский и т.п.),
+// для которых bufio.ScanWords неприменим
+type MorphTokenizer interface {
+	Segment(string) []Token
+}
+
+// MorphTokenizerAdapter адаптирует MorphTokenizer к интерфейсу Tokenizer, потоково
+// прогоняя Base (или Surface, если Base не заполнен) через стандартный пайплайн Map/Filter
+type MorphTokenizerAdapter struct {
+	segmenter MorphTokenizer
+
+	transforms []Mapper
+	filters    []Predicate
+	bufferSize int
+
+	keepPOS map[string]struct{} // Если не пусто, сохраняются только токены с POS из этого набора
+}
+
+// MorphOption содержит настройки MorphTokenizerAdapter
+type MorphOption func(*MorphTokenizerAdapter)
+
+// NewMorphTokenizerAdapter оборачивает морфологический анализатор m в Tokenizer
+func NewMorphTokenizerAdapter(m MorphTokenizer, opts ...MorphOption) *MorphTokenizerAdapter {
+	t := &MorphTokenizerAdapter{
+		segmenter:  m,
+		bufferSize: 100,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// KeepPOS ограничивает токены только перечисленными частями речи (например,
+// KeepPOS("名詞", "動詞") оставит только существительные и глаголы), что заметно
+// повышает точность наивного Байеса на CJK-текстах за счет отбрасывания служебных слов
+func KeepPOS(pos ...string) MorphOption {
+	return func(t *MorphTokenizerAdapter) {
+		set := make(map[string]struct{}, len(pos))
+		for _, p := range pos {
+			set[p] = struct{}{}
+		}
+		t.keepPOS = set
+	}
+}
+
+// MorphTransforms перезаписывает мапперы, применяемые к токенам после сегментации
+func MorphTransforms(m ...Mapper) MorphOption {
+	return func(t *MorphTokenizerAdapter) {
+		t.transforms = m
+	}
+}
+
+// MorphFilters перезаписывает фильтры, применяемые к токенам после сегментации
+func MorphFilters(f ...Predicate) MorphOption {
+	return func(t *MorphTokenizerAdapter) {
+		t.filters = f
+	}
+}
+
+// Tokenize разбивает data с помощью настроенного MorphTokenizer и прогоняет
+// полученные токены через пайплайн Map/Filter. Позиция каждого токена определяется
+// поиском его Surface в data начиная с конца предыдущего токена, поскольку
+// MorphTokenizer возвращает токены без смещений
+func (t *MorphTokenizerAdapter) Tokenize(data string) chan TokenInfo {
+	tokens := make(chan TokenInfo, t.bufferSize)
+
+	go func() {
+		defer close(tokens)
+
+		cursor := 0
+		for _, tok := range t.segmenter.Segment(data) {
+			start, end := cursor, cursor+len(tok.Surface)
+			if idx := strings.Index(data[cursor:], tok.Surface); idx >= 0 {
+				start = cursor + idx
+				end = start + len(tok.Surface)
+			}
+			cursor = end
+
+			if !t.keepToken(tok) {
+				continue
+			}
+
+			text := tok.Base
+			if text == "" {
+				text = tok.Surface
+			}
+			tokens <- TokenInfo{Text: text, Start: start, End: end}
+		}
+	}()
+
+	return t.pipeline(tokens)
+}
+
+// pipeline применяет функции фильтров и мапперов на входной поток токенов
+func (t *MorphTokenizerAdapter) pipeline(in chan TokenInfo) chan TokenInfo {
+	return Map(Filter(in, t.filters...), t.transforms...)
+}
+
+// keepToken определяет, проходит ли tok ограничение по частям речи KeepPOS
+func (t *MorphTokenizerAdapter) keepToken(tok Token) bool {
+	if len(t.keepPOS) == 0 {
+		return true
+	}
+	_, ok := t.keepPOS[tok.POS]
+	return ok
+}
+
+// ConfigFingerprint возвращает упорядоченный список компонентов пайплайна для фингерпринта
+// конфигурации модели: тип используемого морфологического анализатора и набор KeepPOS.
+// Теги KeepPOS сортируются перед склейкой, чтобы фингерпринт не зависел от порядка
+// аргументов, переданных в KeepPOS, но различал непересекающиеся наборы тегов
+func (t *MorphTokenizerAdapter) ConfigFingerprint() []string {
+	components := []string{fmt.Sprintf("morph:%T", t.segmenter)}
+
+	if len(t.keepPOS) > 0 {
+		tags := make([]string, 0, len(t.keepPOS))
+		for pos := range t.keepPOS {
+			tags = append(tags, pos)
+		}
+		sort.Strings(tags)
+		components = append(components, "keepPOS:"+strings.Join(tags, ","))
+	}
+	return components
+}
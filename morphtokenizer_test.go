@@ -0,0 +1,65 @@
+package classifier
+
+import "testing"
+
+// fakeSegmenter реализует MorphTokenizer статичным списком токенов, не зависящим от text
+type fakeSegmenter struct {
+	tokens []Token
+}
+
+func (s *fakeSegmenter) Segment(text string) []Token {
+	return s.tokens
+}
+
+// TestMorphTokenizerAdapterKeepPOS проверяет, что KeepPOS отбрасывает токены, часть
+// речи которых не входит в переданный набор, и использует Base вместо Surface,
+// когда Base заполнен
+func TestMorphTokenizerAdapterKeepPOS(t *testing.T) {
+	seg := &fakeSegmenter{tokens: []Token{
+		{Surface: "猫", POS: "名詞"},
+		{Surface: "が", POS: "助詞"},
+		{Surface: "走る", Base: "走り", POS: "動詞"},
+	}}
+
+	adapter := NewMorphTokenizerAdapter(seg, MorphTransforms(), MorphFilters(), KeepPOS("名詞", "動詞"))
+
+	var got []string
+	for tk := range adapter.Tokenize("猫が走る") {
+		got = append(got, tk.Text)
+	}
+
+	want := []string{"猫", "走り"}
+	if len(got) != len(want) {
+		t.Fatalf("Tokenize() = %v, want %v", got, want)
+	}
+	for i, g := range got {
+		if g != want[i] {
+			t.Errorf("Tokenize()[%d] = %q, want %q", i, g, want[i])
+		}
+	}
+}
+
+// TestMorphTokenizerAdapterConfigFingerprint проверяет, что фингерпринт конфигурации
+// различает непересекающиеся наборы KeepPOS, даже если размер набора одинаков
+func TestMorphTokenizerAdapterConfigFingerprint(t *testing.T) {
+	seg := &fakeSegmenter{}
+
+	nouns := NewMorphTokenizerAdapter(seg, KeepPOS("名詞"))
+	verbs := NewMorphTokenizerAdapter(seg, KeepPOS("動詞"))
+
+	nounsFp := nouns.ConfigFingerprint()
+	verbsFp := verbs.ConfigFingerprint()
+
+	if len(nounsFp) != len(verbsFp) {
+		t.Fatalf("ConfigFingerprint() lengths differ: %v vs %v", nounsFp, verbsFp)
+	}
+	equal := true
+	for i := range nounsFp {
+		if nounsFp[i] != verbsFp[i] {
+			equal = false
+		}
+	}
+	if equal {
+		t.Errorf("ConfigFingerprint() = %v for both KeepPOS(\"名詞\") and KeepPOS(\"動詞\"), want distinct fingerprints", nounsFp)
+	}
+}
@@ -0,0 +1,188 @@
+package classifier
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// NgramMode задает режим построения n-грамм
+type NgramMode int
+
+const (
+	NgramChar NgramMode = iota // Символьные n-граммы внутри каждого слова
+	NgramWord                  // n-граммы по потоку слов
+)
+
+// String возвращает текстовое представление режима n-грамм
+func (m NgramMode) String() string {
+	if m == NgramWord {
+		return "word"
+	}
+	return "char"
+}
+
+// NgramTokenizer разбивает документ на символьные или словесные n-граммы длины от minN до maxN
+type NgramTokenizer struct {
+	minN, maxN int
+	mode       NgramMode
+	boundary   bool // добавлять ли маркеры "^"/"$" границ слова для символьных n-грамм
+
+	transforms []Mapper
+	filters    []Predicate
+	bufferSize int
+
+	components []string // Упорядоченный список компонентов пайплайна, используемый для фингерпринта конфигурации
+}
+
+// NgramOption содержит настройки NgramTokenizer
+type NgramOption func(*NgramTokenizer)
+
+// NewNgramTokenizer создает tokenizer, разбивающий слова документа на n-граммы длины
+// от minN до maxN в режиме mode
+func NewNgramTokenizer(minN, maxN int, mode NgramMode, opts ...NgramOption) *NgramTokenizer {
+	t := &NgramTokenizer{
+		minN:       minN,
+		maxN:       maxN,
+		mode:       mode,
+		bufferSize: 100,
+		transforms: []Mapper{strings.ToLower},
+		components: []string{fmt.Sprintf("ngram:%s:%d-%d", mode, minN, maxN)},
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// ConfigFingerprint возвращает упорядоченный список компонентов пайплайна n-грамм,
+// используемый для вычисления хэша конфигурации модели
+func (t *NgramTokenizer) ConfigFingerprint() []string {
+	return append([]string(nil), t.components...)
+}
+
+// WithWordBoundaries включает маркеры "^"/"$" в начале/конце символьных n-грамм слова,
+// позволяя отличать префиксные/суффиксные n-граммы от внутренних
+func WithWordBoundaries() NgramOption {
+	return func(t *NgramTokenizer) {
+		t.boundary = true
+		t.components = append(t.components, "boundary")
+	}
+}
+
+// NgramTransforms перезаписывает мапперы, применяемые к построенным n-граммам
+func NgramTransforms(m ...Mapper) NgramOption {
+	return func(t *NgramTokenizer) {
+		t.transforms = m
+		t.components = append(t.components, mapperFingerprint("transforms", m))
+	}
+}
+
+// NgramFilters перезаписывает фильтры, применяемые к построенным n-граммам
+func NgramFilters(f ...Predicate) NgramOption {
+	return func(t *NgramTokenizer) {
+		t.filters = f
+		t.components = append(t.components, predicateFingerprint("filters", f))
+	}
+}
+
+// Tokenize разбивает переданный документ на n-граммы
+func (t *NgramTokenizer) Tokenize(data string) chan TokenInfo {
+	words := scanWordsWithOffsets(data)
+	return t.pipeline(t.fanOut(words))
+}
+
+// pipeline применяет функции фильтров и мапперов на входной поток n-грамм
+func (t *NgramTokenizer) pipeline(in chan TokenInfo) chan TokenInfo {
+	return Map(Filter(in, t.filters...), t.transforms...)
+}
+
+// fanOut разбивает слова words на поток n-грамм в соответствии с t.mode
+func (t *NgramTokenizer) fanOut(words []TokenInfo) chan TokenInfo {
+	ngrams := make(chan TokenInfo, t.bufferSize)
+
+	go func() {
+		defer close(ngrams)
+
+		if t.mode == NgramWord {
+			t.emitWordNgrams(words, ngrams)
+			return
+		}
+
+		for _, w := range words {
+			for _, g := range charNgrams(w, t.minN, t.maxN, t.boundary) {
+				ngrams <- g
+			}
+		}
+	}()
+
+	return ngrams
+}
+
+// emitWordNgrams отправляет в out все словесные n-граммы длины от t.minN до t.maxN,
+// построенные скользящим окном по words; позиция n-граммы охватывает окно от начала первого до конца последнего слова
+func (t *NgramTokenizer) emitWordNgrams(words []TokenInfo, out chan TokenInfo) {
+	for n := t.minN; n <= t.maxN; n++ {
+		for i := 0; i+n <= len(words); i++ {
+			window := words[i : i+n]
+
+			text := make([]string, len(window))
+			for j, w := range window {
+				text[j] = w.Text
+			}
+
+			out <- TokenInfo{
+				Text:  strings.Join(text, " "),
+				Start: window[0].Start,
+				End:   window[len(window)-1].End,
+			}
+		}
+	}
+}
+
+// charNgrams строит символьные n-граммы слова w длины от minN до maxN, сохраняя их байтовые
+// смещения в исходном документе. При boundary=true слово дополнительно оборачивается
+// маркерами "^"/"$", попадающими в крайние n-граммы; сами маркеры позиции не занимают
+func charNgrams(w TokenInfo, minN, maxN int, boundary bool) []TokenInfo {
+	original := []rune(w.Text)
+
+	// positions[i] — абсолютное байтовое смещение начала i-й руны слова (positions[len] == w.End)
+	positions := make([]int, len(original)+1)
+	pos := w.Start
+	for i, r := range original {
+		positions[i] = pos
+		pos += utf8.RuneLen(r)
+	}
+	positions[len(original)] = w.End
+
+	runes := original
+	if boundary {
+		bounded := make([]rune, 0, len(original)+2)
+		bounded = append(bounded, '^')
+		bounded = append(bounded, original...)
+		bounded = append(bounded, '$')
+		runes = bounded
+
+		boundedPositions := make([]int, len(bounded)+1)
+		boundedPositions[0] = w.Start
+		for i := 0; i <= len(original); i++ {
+			boundedPositions[i+1] = positions[i]
+		}
+		boundedPositions[len(original)+2] = w.End
+		positions = boundedPositions
+	}
+
+	var grams []TokenInfo
+	for n := minN; n <= maxN && n <= len(runes); n++ {
+		for i := 0; i+n <= len(runes); i++ {
+			grams = append(grams, TokenInfo{
+				Text:  string(runes[i : i+n]),
+				Start: positions[i],
+				End:   positions[i+n],
+			})
+		}
+	}
+	return grams
+}
@@ -0,0 +1,44 @@
+package classifier
+
+import "testing"
+
+// TestNgramTokenizerCharMode проверяет построение символьных n-грамм слова,
+// в том числе с маркерами границ слова "^"/"$"
+func TestNgramTokenizerCharMode(t *testing.T) {
+	tok := NewNgramTokenizer(2, 2, NgramChar, NgramTransforms(), NgramFilters(), WithWordBoundaries())
+
+	var got []string
+	for tk := range tok.Tokenize("го") {
+		got = append(got, tk.Text)
+	}
+
+	want := []string{"^г", "го", "о$"}
+	if len(got) != len(want) {
+		t.Fatalf("Tokenize() = %v, want %v", got, want)
+	}
+	for i, g := range got {
+		if g != want[i] {
+			t.Errorf("Tokenize()[%d] = %q, want %q", i, g, want[i])
+		}
+	}
+}
+
+// TestNgramTokenizerWordMode проверяет построение словесных n-грамм скользящим окном
+func TestNgramTokenizerWordMode(t *testing.T) {
+	tok := NewNgramTokenizer(2, 2, NgramWord, NgramTransforms(), NgramFilters())
+
+	var got []string
+	for tk := range tok.Tokenize("мама мыла раму") {
+		got = append(got, tk.Text)
+	}
+
+	want := []string{"мама мыла", "мыла раму"}
+	if len(got) != len(want) {
+		t.Fatalf("Tokenize() = %v, want %v", got, want)
+	}
+	for i, g := range got {
+		if g != want[i] {
+			t.Errorf("Tokenize()[%d] = %q, want %q", i, g, want[i])
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package classifier
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// ConfigFingerprinter реализуется Tokenizer-ами, способными описать свой пайплайн
+// упорядоченным списком компонентов (имя+аргументы) для фингерпринта конфигурации
+type ConfigFingerprinter interface {
+	ConfigFingerprint() []string
+}
+
+// PipelineMismatchError возвращается Load и Merge, если конфигурация токенизатора
+// сохраненной или объединяемой модели не совпадает с конфигурацией текущего классификатора
+type PipelineMismatchError struct{}
+
+func (e *PipelineMismatchError) Error() string {
+	return "[AstrA] Конфигурация токенизатора модели не совпадает с текущей"
+}
+
+// ErrPipelineMismatch сигнализирует о несовпадении хэша конфигурации пайплайна токенизации
+var ErrPipelineMismatch = &PipelineMismatchError{}
+
+// modelState описывает сериализуемое состояние модели классификатора
+type modelState struct {
+	MapTokenToCategory  map[string]map[string]int
+	CategoriesCount     map[string]int
+	CategoryTokenTotals map[string]int
+	Vocabulary          map[string]struct{}
+	Alpha               float64
+	ConfigHash          [32]byte
+}
+
+// Save сериализует обученную модель классификатора в w вместе с хэшем конфигурации
+// текущего пайплайна токенизации
+func (c *Classifier) Save(w io.Writer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	state := modelState{
+		MapTokenToCategory:  c.MapTokenToCategory,
+		CategoriesCount:     c.CategoriesCount,
+		CategoryTokenTotals: c.categoryTokenTotals,
+		Vocabulary:          c.vocabulary,
+		Alpha:               c.alpha,
+		ConfigHash:          c.configHash(),
+	}
+
+	return gob.NewEncoder(w).Encode(state)
+}
+
+// Load десериализует модель из r в c. Если хэш конфигурации пайплайна токенизации
+// сохраненной модели не совпадает с текущим, возвращается ErrPipelineMismatch
+func (c *Classifier) Load(r io.Reader) error {
+	var state modelState
+	if err := gob.NewDecoder(r).Decode(&state); err != nil {
+		return fmt.Errorf("[AstrA] Не удалось прочитать модель: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state.ConfigHash != c.configHash() {
+		return ErrPipelineMismatch
+	}
+
+	c.MapTokenToCategory = state.MapTokenToCategory
+	c.CategoriesCount = state.CategoriesCount
+	c.categoryTokenTotals = state.CategoryTokenTotals
+	c.vocabulary = state.Vocabulary
+	c.alpha = state.Alpha
+	return nil
+}
+
+// Merge объединяет обученную модель other в c, если их пайплайны токенизации совпадают
+// по хэшу конфигурации. Используется для объединения шардов, обученных независимо,
+// например, в разных воркерах распределенного обучения
+func (c *Classifier) Merge(other *Classifier) error {
+	// Снимок состояния other строится под его собственным RLock и без удержания
+	// c.mu, чтобы конкурентные a.Merge(b) и b.Merge(a) не заходили в клинч
+	// на взаимно противоположном порядке захвата мьютексов
+	other.mu.RLock()
+	otherTokens := make(map[string]map[string]int, len(other.MapTokenToCategory))
+	for token, cats := range other.MapTokenToCategory {
+		catsCopy := make(map[string]int, len(cats))
+		for cat, count := range cats {
+			catsCopy[cat] = count
+		}
+		otherTokens[token] = catsCopy
+	}
+
+	otherCategories := make(map[string]int, len(other.CategoriesCount))
+	for cat, count := range other.CategoriesCount {
+		otherCategories[cat] = count
+	}
+
+	otherTotals := make(map[string]int, len(other.categoryTokenTotals))
+	for cat, total := range other.categoryTokenTotals {
+		otherTotals[cat] = total
+	}
+
+	otherVocabulary := make(map[string]struct{}, len(other.vocabulary))
+	for token := range other.vocabulary {
+		otherVocabulary[token] = struct{}{}
+	}
+
+	otherHash := other.configHash()
+	other.mu.RUnlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if otherHash != c.configHash() {
+		return ErrPipelineMismatch
+	}
+
+	for token, cats := range otherTokens {
+		if _, ok := c.MapTokenToCategory[token]; !ok {
+			c.MapTokenToCategory[token] = make(map[string]int)
+		}
+		for cat, count := range cats {
+			c.MapTokenToCategory[token][cat] += count
+		}
+	}
+
+	for cat, count := range otherCategories {
+		c.CategoriesCount[cat] += count
+	}
+
+	for cat, total := range otherTotals {
+		c.categoryTokenTotals[cat] += total
+	}
+
+	for token := range otherVocabulary {
+		c.vocabulary[token] = struct{}{}
+	}
+
+	return nil
+}
+
+// configHash вычисляет SHA-256 хэш по параметру сглаживания и упорядоченному списку
+// компонентов пайплайна токенизации, фингерпринтируя тем самым конфигурацию модели целиком
+func (c *Classifier) configHash() [32]byte {
+	h := sha256.New()
+	fmt.Fprintf(h, "alpha:%v\n", c.alpha)
+
+	if fp, ok := c.tokenizer.(ConfigFingerprinter); ok {
+		for _, component := range fp.ConfigFingerprint() {
+			fmt.Fprintf(h, "%s\n", component)
+		}
+	} else {
+		fmt.Fprint(h, "tokenizer:unknown\n")
+	}
+
+	var sum [32]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
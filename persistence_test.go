@@ -0,0 +1,31 @@
+package classifier
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMergeMutualNoDeadlock воспроизводит конкурентные a.Merge(b) и b.Merge(a)
+// ("объединение шардов распределенного обучения") и проверяет, что оба вызова
+// завершаются без клинча на противоположном порядке захвата мьютексов
+func TestMergeMutualNoDeadlock(t *testing.T) {
+	a := New()
+	b := New()
+	a.Train("привет мир", "greeting")
+	b.Train("добрый день", "greeting")
+
+	done := make(chan error, 2)
+	go func() { done <- a.Merge(b) }()
+	go func() { done <- b.Merge(a) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Merge returned error: %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Merge deadlocked on mutual merge")
+		}
+	}
+}
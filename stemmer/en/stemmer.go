@@ -0,0 +1,312 @@
+// Package en реализует упрощенный стеммер английского языка по алгоритму Porter2
+// (см. https://snowballstem.org/algorithms/english/stemmer.html).
+package en
+
+import "strings"
+
+const vowels = "aeiouy"
+
+// Stem приводит слово word к его основе по алгоритму Porter2
+func Stem(word string) string {
+	word = strings.ToLower(word)
+	if len([]rune(word)) <= 2 {
+		return word
+	}
+
+	word = normalizeApostrophes(word)
+	word = markExceptionalY(word)
+
+	word = step0(word)
+	word = step1a(word)
+	if isShortWord(word) {
+		return strings.ReplaceAll(word, "Y", "y")
+	}
+
+	r1 := r1Index(word)
+	word = step1b(word, r1)
+	word = step1c(word)
+
+	r1 = r1Index(word)
+	r2 := r2Index(word, r1)
+	word = step2(word, r1)
+	word = step3(word, r1, r2)
+	word = step4(word, r2)
+	word = step5(word, r1, r2)
+
+	return strings.ReplaceAll(word, "Y", "y")
+}
+
+// normalizeApostrophes приводит типографские апострофы к "'" и убирает начальный апостроф
+func normalizeApostrophes(w string) string {
+	w = strings.NewReplacer("’", "'", "‘", "'").Replace(w)
+	w = strings.TrimPrefix(w, "'")
+	return w
+}
+
+// markExceptionalY помечает "y" как согласную (заглавной "Y"), если она стоит в начале слова
+// или сразу после гласной
+func markExceptionalY(w string) string {
+	r := []rune(w)
+	for i, c := range r {
+		if c == 'y' && (i == 0 || strings.ContainsRune(vowels, r[i-1])) {
+			r[i] = 'Y'
+		}
+	}
+	return string(r)
+}
+
+// r1Index возвращает начало региона R1: после первой пары гласная-согласная,
+// со стандартными исключениями Porter2 для слов вида "gener", "commun", "arsen"
+func r1Index(w string) int {
+	for _, pre := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(w, pre) {
+			return len(pre)
+		}
+	}
+	return regionAfter(w, 0)
+}
+
+// r2Index возвращает начало региона R2: региона после пары гласная-согласная внутри R1
+func r2Index(w string, r1 int) int {
+	if r1 > len(w) {
+		return len(w)
+	}
+	return regionAfter(w, r1)
+}
+
+// regionAfter ищет ближайшую после from пару гласная-согласная и возвращает индекс следующего символа
+func regionAfter(w string, from int) int {
+	r := []rune(w)
+	for i := from; i < len(r)-1; i++ {
+		if strings.ContainsRune(vowels, r[i]) && !strings.ContainsRune(vowels, r[i+1]) {
+			return i + 2
+		}
+	}
+	return len(r)
+}
+
+func inRegion(w string, region, sufLen int) bool {
+	return len([]rune(w))-sufLen >= region
+}
+
+// step0 отбрасывает суффиксы множественного числа притяжательной формы: 's', 's, '
+func step0(w string) string {
+	for _, suf := range []string{"'s'", "'s", "'"} {
+		if strings.HasSuffix(w, suf) {
+			return strings.TrimSuffix(w, suf)
+		}
+	}
+	return w
+}
+
+// step1a обрабатывает окончания множественного числа и герундия/причастия прошедшего времени верхнего уровня
+func step1a(w string) string {
+	switch {
+	case strings.HasSuffix(w, "sses"):
+		return strings.TrimSuffix(w, "sses") + "ss"
+	case strings.HasSuffix(w, "ied"), strings.HasSuffix(w, "ies"):
+		stem := w[:len(w)-3]
+		if len([]rune(stem)) > 1 {
+			return stem + "i"
+		}
+		return stem + "ie"
+	case strings.HasSuffix(w, "us"), strings.HasSuffix(w, "ss"):
+		return w
+	case strings.HasSuffix(w, "s"):
+		stem := w[:len(w)-1]
+		if containsVowel(stem) {
+			return stem
+		}
+		return w
+	}
+	return w
+}
+
+func containsVowel(s string) bool {
+	for _, r := range s {
+		if strings.ContainsRune(vowels, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isShortWord определяет "short word" по правилам Porter2: заканчивается на
+// гласная+согласная и R1 пуст (используется, чтобы пропустить шаги 1b/1c для коротких слов)
+func isShortWord(w string) bool {
+	r := []rune(w)
+	if len(r) < 2 {
+		return false
+	}
+	last, prev := r[len(r)-1], r[len(r)-2]
+	endsVC := !strings.ContainsRune(vowels, last) && last != 'w' && last != 'x' && last != 'Y' && strings.ContainsRune(vowels, prev)
+	return endsVC && r1Index(w) >= len(r)
+}
+
+// step1b обрабатывает окончания -eed/-eedly, -ed/-edly/-ing/-ingly
+func step1b(w string, r1 int) string {
+	for _, suf := range []string{"eedly", "eed"} {
+		if strings.HasSuffix(w, suf) {
+			if inRegion(w, r1, len(suf)) {
+				return strings.TrimSuffix(w, suf) + "ee"
+			}
+			return w
+		}
+	}
+
+	for _, suf := range []string{"ingly", "edly", "ing", "ed"} {
+		if strings.HasSuffix(w, suf) {
+			stem := strings.TrimSuffix(w, suf)
+			if !containsVowel(stem) {
+				return w
+			}
+			return restoreAfterShortSuffix(stem)
+		}
+	}
+	return w
+}
+
+// restoreAfterShortSuffix применяет правила восстановления после удаления -ed/-ing:
+// двойные "at/bl/iz" -> добавить "e"; удвоенная согласная (не l/s/z) -> убрать одну;
+// short word -> добавить "e"
+func restoreAfterShortSuffix(stem string) string {
+	switch {
+	case strings.HasSuffix(stem, "at"), strings.HasSuffix(stem, "bl"), strings.HasSuffix(stem, "iz"):
+		return stem + "e"
+	case endsWithDoubleConsonant(stem) && !strings.HasSuffix(stem, "l") && !strings.HasSuffix(stem, "s") && !strings.HasSuffix(stem, "z"):
+		return stem[:len(stem)-1]
+	case isShortWord(stem):
+		return stem + "e"
+	}
+	return stem
+}
+
+func endsWithDoubleConsonant(s string) bool {
+	r := []rune(s)
+	if len(r) < 2 {
+		return false
+	}
+	a, b := r[len(r)-1], r[len(r)-2]
+	return a == b && !strings.ContainsRune(vowels, a)
+}
+
+// step1c меняет конечную "y"/"Y" на "i", если перед ней стоит согласная и эта согласная
+// не является единственной буквой перед "y" без гласной (иначе "happy" -> "happi",
+// но "sky"/"cry"/"ply" остаются без изменений, так как стоящий перед "y" слог
+// не содержит ни одной гласной)
+func step1c(w string) string {
+	r := []rune(w)
+	if len(r) <= 2 {
+		return w
+	}
+	last := r[len(r)-1]
+	if (last == 'y' || last == 'Y') && !strings.ContainsRune(vowels, r[len(r)-2]) {
+		stem := string(r[:len(r)-1])
+		if containsVowel(stem) {
+			r[len(r)-1] = 'i'
+			return string(r)
+		}
+	}
+	return w
+}
+
+var step2Suffixes = []struct{ suf, repl string }{
+	{"ization", "ize"}, {"ational", "ate"}, {"fulness", "ful"}, {"ousness", "ous"},
+	{"iveness", "ive"}, {"tional", "tion"}, {"biliti", "ble"}, {"lessli", "less"},
+	{"entli", "ent"}, {"ation", "ate"}, {"alism", "al"}, {"aliti", "al"},
+	{"ousli", "ous"}, {"iviti", "ive"}, {"fulli", "ful"}, {"enci", "ence"},
+	{"anci", "ance"}, {"abli", "able"}, {"izer", "ize"}, {"ator", "ate"},
+	{"alli", "al"}, {"bli", "ble"}, {"ogi", "og"}, {"li", ""},
+}
+
+// step2 заменяет производные окончания, лежащие в R1, на их канонические формы
+func step2(w string, r1 int) string {
+	for _, s := range step2Suffixes {
+		if strings.HasSuffix(w, s.suf) && inRegion(w, r1, len(s.suf)) {
+			if s.suf == "li" && !endsWithValidLiPredecessor(w, len(s.suf)) {
+				continue
+			}
+			if s.suf == "ogi" && !strings.HasSuffix(w[:len(w)-3], "l") {
+				continue
+			}
+			return w[:len(w)-len(s.suf)] + s.repl
+		}
+	}
+	return w
+}
+
+func endsWithValidLiPredecessor(w string, sufLen int) bool {
+	stem := w[:len(w)-sufLen]
+	if stem == "" {
+		return false
+	}
+	return strings.ContainsRune("cdeghkmnrt", rune(stem[len(stem)-1]))
+}
+
+var step3Suffixes = []struct {
+	suf, repl string
+	needR2    bool
+}{
+	{"ational", "ate", false}, {"tional", "tion", false}, {"alize", "al", false},
+	{"icate", "ic", false}, {"iciti", "ic", false}, {"ical", "ic", false},
+	{"ful", "", false}, {"ness", "", false}, {"ative", "", true},
+}
+
+// step3 обрабатывает суффиксы уровня 3, некоторые требуют принадлежности к R2 (например "ative")
+func step3(w string, r1, r2 int) string {
+	for _, s := range step3Suffixes {
+		if strings.HasSuffix(w, s.suf) && inRegion(w, r1, len(s.suf)) {
+			if s.needR2 && !inRegion(w, r2, len(s.suf)) {
+				continue
+			}
+			return w[:len(w)-len(s.suf)] + s.repl
+		}
+	}
+	return w
+}
+
+var step4Suffixes = []string{
+	"ement", "ance", "ence", "able", "ible", "ment", "ant", "ent", "ism", "ate",
+	"iti", "ous", "ive", "ize", "ion", "al", "er", "ic",
+}
+
+// step4 удаляет оставшиеся суффиксы, лежащие в R2 ("ion" дополнительно требует, чтобы
+// перед ним стояла "s" или "t")
+func step4(w string, r2 int) string {
+	for _, suf := range step4Suffixes {
+		if strings.HasSuffix(w, suf) && inRegion(w, r2, len(suf)) {
+			stem := w[:len(w)-len(suf)]
+			if suf == "ion" && !(strings.HasSuffix(stem, "s") || strings.HasSuffix(stem, "t")) {
+				continue
+			}
+			return stem
+		}
+	}
+	return w
+}
+
+// step5 отбрасывает конечную "e" (с ограничениями по R1/R2) и упрощает удвоенную конечную "l" в R2
+func step5(w string, r1, r2 int) string {
+	if strings.HasSuffix(w, "e") {
+		stem := w[:len(w)-1]
+		if inRegion(w, r2, 1) || (inRegion(w, r1, 1) && !endsShortSyllableWord(stem)) {
+			return stem
+		}
+	}
+	if strings.HasSuffix(w, "ll") && inRegion(w, r2, 1) {
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// endsShortSyllableWord проверяет, образует ли stem короткий слог (согласная-гласная-согласная,
+// не w/x/Y на конце) — в этом случае конечная "e" не отбрасывается
+func endsShortSyllableWord(stem string) bool {
+	r := []rune(stem)
+	if len(r) < 3 {
+		return false
+	}
+	c1, v, c2 := r[len(r)-3], r[len(r)-2], r[len(r)-1]
+	return !strings.ContainsRune(vowels, c1) && strings.ContainsRune(vowels, v) &&
+		!strings.ContainsRune(vowels, c2) && c2 != 'w' && c2 != 'x' && c2 != 'Y'
+}
@@ -0,0 +1,31 @@
+package en
+
+import "testing"
+
+// TestStemTerminalY проверяет классическую пару happy/sky: "y" меняется на "i" только
+// когда стоящий перед ней слог содержит гласную, иначе слово остается без изменений
+func TestStemTerminalY(t *testing.T) {
+	cases := map[string]string{
+		"happy": "happi",
+		"sky":   "sky",
+		"cry":   "cry",
+		"dry":   "dry",
+		"fly":   "fly",
+		"fry":   "fry",
+		"shy":   "shy",
+		"sly":   "sly",
+		"spy":   "spy",
+		"sty":   "sty",
+		"try":   "try",
+		"why":   "why",
+		"wry":   "wry",
+		"ply":   "ply",
+		"pry":   "pry",
+	}
+
+	for word, want := range cases {
+		if got := Stem(word); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package ru реализует упрощенный стеммер русского языка по алгоритму Snowball
+// (см. http://snowball.tartarus.org/algorithms/russian/stemmer.html).
+package ru
+
+import "strings"
+
+const vowels = "аеиоуыэюя"
+
+var perfectiveGerundEndings1 = []string{"в", "вши", "вшись"}
+var perfectiveGerundEndings2 = []string{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"}
+var reflexiveEndings = []string{"ся", "сь"}
+var adjectiveEndings = []string{
+	"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+	"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+}
+var participleEndings1 = []string{"ем", "нн", "вш", "ющ", "щ"}
+var participleEndings2 = []string{"ивш", "ывш", "ующ"}
+var verbEndings1 = []string{
+	"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно",
+}
+var verbEndings2 = []string{
+	"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+	"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
+}
+var nounEndings = []string{
+	"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й",
+	"иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я",
+}
+var superlativeEndings = []string{"ейш", "ейше"}
+var derivationalEndings = []string{"ост", "ость"}
+
+// Stem приводит слово word к его основе по алгоритму русского Snowball-стеммера.
+// Алгоритм работает только с кириллицей: прочие слова возвращаются лишь приведенными
+// к нижнему регистру
+func Stem(word string) string {
+	word = strings.ToLower(word)
+	runes := []rune(word)
+	if !containsVowel(runes) {
+		return word
+	}
+
+	rv := rvIndex(runes)
+	if rv < 0 {
+		return word
+	}
+
+	r2 := r2Index(runes, rv)
+
+	stem := string(runes)
+
+	// Step 1: PERFECTIVE GERUND, иначе REFLEXIVE + (ADJECTIVAL|VERB|NOUN).
+	// Группа 1 ("в"/"вши"/"вшись") снимается только если ей предшествует "а" или "я"
+	if s, ok := removeFirstMatch(stem, rv, perfectiveGerundEndings2); ok {
+		stem = s
+	} else if s, ok := removeGerundGroup1(stem, rv, perfectiveGerundEndings1); ok {
+		stem = s
+	} else {
+		if s, ok := removeFirstMatch(stem, rv, reflexiveEndings); ok {
+			stem = s
+		}
+
+		if s, ok := removeFirstMatch(stem, rv, adjectiveEndings); ok {
+			stem = s
+			if s2, ok2 := removeFirstMatch(stem, rv, participleEndings2); ok2 {
+				stem = s2
+			} else if s2, ok2 := removeFirstMatch(stem, rv, participleEndings1); ok2 {
+				stem = s2
+			}
+		} else if s, ok := removeFirstMatch(stem, rv, verbEndings2); ok {
+			stem = s
+		} else if s, ok := removeFirstMatch(stem, rv, verbEndings1); ok {
+			stem = s
+		} else if s, ok := removeFirstMatch(stem, rv, nounEndings); ok {
+			stem = s
+		}
+	}
+
+	// Step 2: окончание "и" в RV
+	if strings.HasSuffix(stem, "и") && len([]rune(stem))-1 >= rv {
+		stem = string([]rune(stem)[:len([]rune(stem))-1])
+	}
+
+	// Step 3: DERIVATIONAL окончания в R2
+	if s, ok := removeFirstMatch(stem, r2, derivationalEndings); ok {
+		stem = s
+	}
+
+	// Step 4: двойное "нн", superlative, мягкий знак
+	sr := []rune(stem)
+	if strings.HasSuffix(stem, "нн") {
+		stem = string(sr[:len(sr)-1])
+	} else if s, ok := removeFirstMatch(stem, rv, superlativeEndings); ok {
+		stem = s
+		if strings.HasSuffix(stem, "нн") {
+			sr = []rune(stem)
+			stem = string(sr[:len(sr)-1])
+		}
+	} else if strings.HasSuffix(stem, "ь") {
+		stem = string(sr[:len(sr)-1])
+	}
+
+	return stem
+}
+
+// containsVowel проверяет, содержит ли слово хотя бы одну гласную
+func containsVowel(runes []rune) bool {
+	for _, r := range runes {
+		if strings.ContainsRune(vowels, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// rvIndex возвращает индекс региона RV: позицию сразу после первой гласной слова
+func rvIndex(runes []rune) int {
+	for i, r := range runes {
+		if strings.ContainsRune(vowels, r) {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// r2Index возвращает индекс региона R2: второй регион после пары гласная-согласная, считая от rv
+func r2Index(runes []rune, rv int) int {
+	r1 := -1
+	for i := rv; i < len(runes)-1; i++ {
+		if strings.ContainsRune(vowels, runes[i]) && !strings.ContainsRune(vowels, runes[i+1]) {
+			r1 = i + 2
+			break
+		}
+	}
+	if r1 < 0 {
+		return len(runes)
+	}
+
+	for i := r1; i < len(runes)-1; i++ {
+		if strings.ContainsRune(vowels, runes[i]) && !strings.ContainsRune(vowels, runes[i+1]) {
+			return i + 2
+		}
+	}
+	return len(runes)
+}
+
+// removeFirstMatch ищет самое длинное окончание из endings, целиком лежащее не раньше regionStart,
+// и отсекает его от stem. Самое длинное окончание выбирается сравнением длин, поэтому
+// порядок окончаний в endings не важен
+func removeFirstMatch(stem string, regionStart int, endings []string) (string, bool) {
+	runes := []rune(stem)
+	best := ""
+	for _, e := range endings {
+		if strings.HasSuffix(stem, e) && len(e) > len(best) {
+			cut := len(runes) - len([]rune(e))
+			if cut >= regionStart {
+				best = e
+			}
+		}
+	}
+	if best == "" {
+		return stem, false
+	}
+	return string(runes[:len(runes)-len([]rune(best))]), true
+}
+
+// removeGerundGroup1 ведет себя как removeFirstMatch, но дополнительно требует,
+// чтобы окончанию непосредственно предшествовала гласная "а" или "я" — таково
+// условие группы 1 PERFECTIVE GERUND в алгоритме русского Snowball-стеммера
+func removeGerundGroup1(stem string, regionStart int, endings []string) (string, bool) {
+	runes := []rune(stem)
+	best := ""
+	for _, e := range endings {
+		if strings.HasSuffix(stem, e) && len(e) > len(best) {
+			cut := len(runes) - len([]rune(e))
+			if cut >= regionStart && cut > 0 && (runes[cut-1] == 'а' || runes[cut-1] == 'я') {
+				best = e
+			}
+		}
+	}
+	if best == "" {
+		return stem, false
+	}
+	return string(runes[:len(runes)-len([]rune(best))]), true
+}
@@ -0,0 +1,15 @@
+package ru
+
+import "testing"
+
+// TestStemNounCaseForms проверяет, что падежные формы одного существительного
+// сводятся к общей основе, включая форму на "-ов", которую раньше ошибочно
+// отрезал PERFECTIVE GERUND группы 1 без проверки предшествующей гласной
+func TestStemNounCaseForms(t *testing.T) {
+	want := "дом"
+	for _, word := range []string{"дом", "дома", "домами", "домов"} {
+		if got := Stem(word); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
@@ -1,24 +1,23 @@
 package classifier
 
 import (
-	"bufio"
+	"fmt"
 	"strings"
+	"unicode"
 )
 
 // Tokenizer содержит методы для обработки входных данных на токены
 type Tokenizer interface {
-	Tokenize(string) chan string
+	Tokenize(string) chan TokenInfo
 }
 
 // Tokenize разбивает переданный документ на токены
-func (t *StdTokenizer) Tokenize(data string) chan string {
-	tokenizer := bufio.NewScanner(strings.NewReader(data))
-	tokenizer.Split(bufio.ScanWords)
-	tokens := make(chan string, t.bufferSize)
+func (t *StdTokenizer) Tokenize(data string) chan TokenInfo {
+	tokens := make(chan TokenInfo, t.bufferSize)
 
 	go func() {
-		for tokenizer.Scan() {
-			tokens <- tokenizer.Text()
+		for _, tok := range scanWordsWithOffsets(data) {
+			tokens <- tok
 		}
 		close(tokens)
 	}()
@@ -26,11 +25,38 @@ func (t *StdTokenizer) Tokenize(data string) chan string {
 	return t.pipeline(tokens)
 }
 
+// scanWordsWithOffsets разбивает data на слова по границам пробельных символов,
+// сохраняя байтовые смещения [Start, End) каждого слова в исходной строке
+func scanWordsWithOffsets(data string) []TokenInfo {
+	var tokens []TokenInfo
+
+	start := -1
+	for i, r := range data {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				tokens = append(tokens, TokenInfo{Text: data[start:i], Start: start, End: i})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, TokenInfo{Text: data[start:], Start: start, End: len(data)})
+	}
+
+	return tokens
+}
+
 // StdTokenizer содержит tokenizer для обработки входных данных по разделителю
 type StdTokenizer struct {
 	transforms []Mapper
 	filters    []Predicate
 	bufferSize int
+
+	components []string // Упорядоченный список компонентов пайплайна, используемый для фингерпринта конфигурации
 }
 
 // StdOption содержит настройки tokenizer
@@ -43,9 +69,10 @@ func NewTokenizer(opts ...StdOption) *StdTokenizer {
 		transforms: []Mapper{ // Токены приводятся в нижний регистр
 			strings.ToLower,
 		},
-		filters: []Predicate{ // Токены фильтруются по массиву стоп-слов
-			IsNotStopWord,
+		filters: []Predicate{ // Токены фильтруются по набору русских стоп-слов
+			StopWordFilter(stopWordSetRU),
 		},
+		components: []string{"lowercase", "stopwords:ru"},
 	}
 
 	for _, opt := range opts {
@@ -56,27 +83,36 @@ func NewTokenizer(opts ...StdOption) *StdTokenizer {
 }
 
 // pipeline применяет функции фильтров и мапперов на входной поток токенов
-func (t *StdTokenizer) pipeline(in chan string) chan string {
+func (t *StdTokenizer) pipeline(in chan TokenInfo) chan TokenInfo {
 	return Map(Filter(in, t.filters...), t.transforms...)
 }
 
+// ConfigFingerprint возвращает упорядоченный список компонентов пайплайна токенизации,
+// используемый для вычисления хэша конфигурации модели
+func (t *StdTokenizer) ConfigFingerprint() []string {
+	return append([]string(nil), t.components...)
+}
+
 // BufferSize определяет размер входящего канала
 func BufferSize(size int) StdOption {
 	return func(t *StdTokenizer) {
 		t.bufferSize = size
+		t.components = append(t.components, fmt.Sprintf("buffer:%d", size))
 	}
 }
 
-// Transforms...
+// Transforms перезаписывает мапперы, применяемые к токенам
 func Transforms(m ...Mapper) StdOption {
 	return func(t *StdTokenizer) {
 		t.transforms = m
+		t.components = append(t.components, mapperFingerprint("transforms", m))
 	}
 }
 
-// Filters...
+// Filters перезаписывает фильтры, применяемые к токенам
 func Filters(f ...Predicate) StdOption {
 	return func(t *StdTokenizer) {
 		t.filters = f
+		t.components = append(t.components, predicateFingerprint("filters", f))
 	}
 }
@@ -1,21 +1,33 @@
 package classifier
 
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
 const defaultBufferSize = 50
 
-// Predicate описывает функцию предиката
+// TokenInfo описывает токен вместе с его байтовыми смещениями [Start, End) в исходном документе
+type TokenInfo struct {
+	Text       string
+	Start, End int
+}
+
+// Predicate описывает функцию предиката, применяемую к тексту токена
 type Predicate func(string) bool
 
-// Mapper описывает функцию-маппер
+// Mapper описывает функцию-маппер, применяемую к тексту токена
 type Mapper func(string) string
 
-// Map применяет функцию f к каждому элементу входного канала
-func Map(vs chan string, f ...Mapper) chan string {
-	stream := make(chan string, defaultBufferSize)
+// Map применяет функции f к тексту каждого токена входного канала, сохраняя его позицию
+func Map(vs chan TokenInfo, f ...Mapper) chan TokenInfo {
+	stream := make(chan TokenInfo, defaultBufferSize)
 
 	go func() {
 		for v := range vs {
 			for _, fn := range f {
-				v = fn(v)
+				v.Text = fn(v.Text)
 			}
 			stream <- v
 		}
@@ -25,9 +37,37 @@ func Map(vs chan string, f ...Mapper) chan string {
 	return stream
 }
 
-// Filter применяет функции фильтров к каждому элементу входного потока
-func Filter(vs chan string, filters ...Predicate) chan string {
-	stream := make(chan string, defaultBufferSize)
+// funcName возвращает runtime-имя функции fn, используемое для фингерпринта конфигурации:
+// в отличие от длины среза, оно различает пайплайны, собранные из разных функций.
+// Аргументы замыканий в имя не входят: замыкания одной функции-конструктора с разными
+// аргументами (например, RemoveLongFilter(5) и RemoveLongFilter(10)) получают одинаковое имя
+func funcName(fn interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+}
+
+// mapperFingerprint возвращает компонент фингерпринта конфигурации для списка мапперов,
+// перечисляя runtime-имя каждой функции вместо одной лишь их длины
+func mapperFingerprint(label string, m []Mapper) string {
+	names := make([]string, len(m))
+	for i, fn := range m {
+		names[i] = funcName(fn)
+	}
+	return label + ":" + strings.Join(names, ",")
+}
+
+// predicateFingerprint возвращает компонент фингерпринта конфигурации для списка предикатов,
+// перечисляя runtime-имя каждой функции вместо одной лишь их длины
+func predicateFingerprint(label string, f []Predicate) string {
+	names := make([]string, len(f))
+	for i, fn := range f {
+		names[i] = funcName(fn)
+	}
+	return label + ":" + strings.Join(names, ",")
+}
+
+// Filter применяет функции фильтров к тексту каждого токена входного потока
+func Filter(vs chan TokenInfo, filters ...Predicate) chan TokenInfo {
+	stream := make(chan TokenInfo, defaultBufferSize)
 	apply := func(text string) bool {
 		for _, f := range filters {
 			if !f(text) {
@@ -38,9 +78,9 @@ func Filter(vs chan string, filters ...Predicate) chan string {
 	}
 
 	go func() {
-		for text := range vs {
-			if apply(text) {
-				stream <- text
+		for v := range vs {
+			if apply(v.Text) {
+				stream <- v
 			}
 		}
 		close(stream)
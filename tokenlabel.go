@@ -0,0 +1,57 @@
+package classifier
+
+import "math"
+
+// TokenLabel описывает результат классификации отдельного токена документа: его текст,
+// наиболее вероятную категорию, вероятности по всем категориям модели и байтовые
+// смещения [Start, End) токена в исходном документе
+type TokenLabel struct {
+	Token      string
+	Category   string
+	Probs      map[string]float64
+	Start, End int
+}
+
+// ClassifyTokens классифицирует каждый выживший после пайплайна токенизации токен
+// документа data по отдельности и возвращает для него TokenLabel с лучшей категорией,
+// вероятностями по категориям и позицией в исходном тексте. Это позволяет подсвечивать
+// дискриминативные для категории фрагменты текста — например, для объяснения решения
+// классификатора или как финальный этап в пайплайне детектирования
+func (c *Classifier) ClassifyTokens(data string) []TokenLabel {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	categories := c.getModelCategories()
+
+	var labels []TokenLabel
+	for tok := range c.tokenizer.Tokenize(data) {
+		logScores := make(map[string]float64, len(categories))
+
+		var bestCat string
+		maxLogScore := math.Inf(-1)
+		for _, cat := range categories {
+			score := math.Log(c.getCategoryPrior(cat)) + math.Log(c.getTokenProb(tok.Text, cat))
+			logScores[cat] = score
+			if score > maxLogScore {
+				maxLogScore = score
+				bestCat = cat
+			}
+		}
+
+		probs := make(map[string]float64, len(logScores))
+		norm := logSumExp(logScores)
+		for cat, score := range logScores {
+			probs[cat] = math.Exp(score - norm)
+		}
+
+		labels = append(labels, TokenLabel{
+			Token:    tok.Text,
+			Category: bestCat,
+			Probs:    probs,
+			Start:    tok.Start,
+			End:      tok.End,
+		})
+	}
+
+	return labels
+}
@@ -0,0 +1,43 @@
+package classifier
+
+import "testing"
+
+// TestClassifyTokensOffsetsAndCategories проверяет, что ClassifyTokens возвращает по
+// одному TokenLabel на выживший токен, с корректными байтовыми смещениями в исходном
+// документе и ожидаемой лучшей категорией для дискриминативных токенов
+func TestClassifyTokensOffsetsAndCategories(t *testing.T) {
+	c := New()
+	c.Train("кот собака хомяк", "animals")
+	c.Train("стул шкаф полка", "furniture")
+
+	data := "кот стул"
+	labels := c.ClassifyTokens(data)
+
+	if len(labels) != 2 {
+		t.Fatalf("ClassifyTokens(%q) = %d labels, want 2", data, len(labels))
+	}
+
+	if labels[0].Token != "кот" || labels[0].Category != "animals" {
+		t.Errorf("labels[0] = %+v, want Token=\"кот\" Category=\"animals\"", labels[0])
+	}
+	if data[labels[0].Start:labels[0].End] != "кот" {
+		t.Errorf("labels[0] offsets [%d:%d] = %q, want \"кот\"", labels[0].Start, labels[0].End, data[labels[0].Start:labels[0].End])
+	}
+
+	if labels[1].Token != "стул" || labels[1].Category != "furniture" {
+		t.Errorf("labels[1] = %+v, want Token=\"стул\" Category=\"furniture\"", labels[1])
+	}
+	if data[labels[1].Start:labels[1].End] != "стул" {
+		t.Errorf("labels[1] offsets [%d:%d] = %q, want \"стул\"", labels[1].Start, labels[1].End, data[labels[1].Start:labels[1].End])
+	}
+
+	for _, l := range labels {
+		sum := 0.0
+		for _, p := range l.Probs {
+			sum += p
+		}
+		if sum < 0.999 || sum > 1.001 {
+			t.Errorf("label %+v Probs sum to %v, want ~1", l, sum)
+		}
+	}
+}